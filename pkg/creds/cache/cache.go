@@ -0,0 +1,246 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package cache provides an in-process cache of AWS credentials with
+// proactive, coalesced background refresh. It is transport- and
+// provider-agnostic: callers supply a FetchFunc closure, so the cache has no
+// dependency on pkg/creds and can be wired in from GetCredentialsC without an
+// import cycle.
+package cache
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/netflix/weep/pkg/aws"
+	"github.com/netflix/weep/pkg/logging"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// DefaultRefreshThreshold is used when credentials.refresh_threshold is unset.
+const DefaultRefreshThreshold = 15 * time.Minute
+
+// FetchFunc retrieves a fresh set of credentials for whatever key the cache
+// was asked to fetch. It's the caller's responsibility to close over the
+// provider, role, and any assume-role chain.
+type FetchFunc func(ctx context.Context) (*aws.Credentials, error)
+
+// Stats summarizes the current state of the cache, for the admin endpoints.
+type Stats struct {
+	Entries int
+	Stale   int
+}
+
+type entry struct {
+	mu         sync.Mutex
+	creds      *aws.Credentials
+	fetch      FetchFunc
+	cancelRefr context.CancelFunc
+}
+
+// Cache caches *aws.Credentials keyed by an opaque string (typically
+// role+ipRestrict+assumeRoleChain), refreshing each entry in the background
+// before it gets within threshold of expiring so callers never block on a
+// live ConsoleMe call once an entry is warm.
+type Cache struct {
+	threshold time.Duration
+	group     singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]*entry
+}
+
+// New returns a Cache that proactively refreshes entries threshold before
+// they expire. A zero threshold uses DefaultRefreshThreshold.
+func New(threshold time.Duration) *Cache {
+	if threshold <= 0 {
+		threshold = DefaultRefreshThreshold
+	}
+	return &Cache{
+		threshold: threshold,
+		entries:   make(map[string]*entry),
+	}
+}
+
+// Get returns cached credentials for key if they still have more than
+// c.threshold of validity left, otherwise calls fetch, coalescing concurrent
+// misses for the same key via single-flight. On a cache hit this never calls
+// fetch.
+func (c *Cache) Get(ctx context.Context, key string, fetch FetchFunc) (*aws.Credentials, error) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok {
+		e.mu.Lock()
+		creds := e.creds
+		e.mu.Unlock()
+		if creds != nil && time.Until(creds.Expiration) > c.threshold {
+			return creds, nil
+		}
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return fetch(ctx)
+	})
+	if err != nil {
+		// Serve stale-but-valid credentials rather than failing the caller,
+		// as long as they haven't truly expired yet.
+		if ok {
+			e.mu.Lock()
+			creds := e.creds
+			e.mu.Unlock()
+			if creds != nil && time.Now().Before(creds.Expiration) {
+				logging.Log.Warnf("credential refresh failed for cache key %q, serving stale credentials until %s: %v", key, creds.Expiration, err)
+				return creds, nil
+			}
+		}
+		return nil, err
+	}
+
+	fresh := v.(*aws.Credentials)
+	c.store(key, fresh, fetch)
+	return fresh, nil
+}
+
+// store saves fresh under key and (re)schedules the background refresh
+// goroutine for that entry.
+func (c *Cache) store(key string, fresh *aws.Credentials, fetch FetchFunc) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	if !ok {
+		e = &entry{fetch: fetch}
+		c.entries[key] = e
+	}
+	c.mu.Unlock()
+
+	e.mu.Lock()
+	e.creds = fresh
+	if e.cancelRefr != nil {
+		e.cancelRefr()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancelRefr = cancel
+	e.mu.Unlock()
+
+	go c.scheduleRefresh(ctx, key, e, fresh)
+}
+
+// scheduleRefresh sleeps until shortly before fresh expires (jittered so
+// many entries don't refresh in lockstep), then refreshes the entry via
+// single-flight so a concurrent caller-driven miss doesn't duplicate work.
+func (c *Cache) scheduleRefresh(ctx context.Context, key string, e *entry, fresh *aws.Credentials) {
+	jitter := 0.75 + rand.Float64()*0.25 // refresh within the last 75-100% of the threshold window
+	wait := time.Until(fresh.Expiration) - time.Duration(float64(c.threshold)*jitter)
+	if wait < 0 {
+		wait = 0
+	}
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(wait):
+	}
+
+	v, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return e.fetch(context.Background())
+	})
+	if err != nil {
+		logging.Log.Warnf("background credential refresh failed for cache key %q, serving stale credentials until %s: %v", key, fresh.Expiration, err)
+		// Try again closer to the real expiration instead of giving up.
+		e.mu.Lock()
+		stale := e.creds
+		e.mu.Unlock()
+		if stale != nil && time.Now().Before(stale.Expiration) {
+			retryCtx, cancel := context.WithCancel(context.Background())
+			e.mu.Lock()
+			e.cancelRefr = cancel
+			e.mu.Unlock()
+			go c.scheduleRefresh(retryCtx, key, e, stale)
+		}
+		return
+	}
+
+	c.store(key, v.(*aws.Credentials), e.fetch)
+}
+
+// Invalidate drops the cached entry for key, if any, and stops its
+// background refresh goroutine. The next Get for key will fetch fresh
+// credentials.
+func (c *Cache) Invalidate(key string) {
+	c.mu.Lock()
+	e, ok := c.entries[key]
+	delete(c.entries, key)
+	c.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	e.mu.Lock()
+	if e.cancelRefr != nil {
+		e.cancelRefr()
+	}
+	e.mu.Unlock()
+}
+
+// Stats reports the current size of the cache and how many entries are
+// serving stale (expired) credentials.
+func (c *Cache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := Stats{Entries: len(c.entries)}
+	for _, e := range c.entries {
+		e.mu.Lock()
+		if e.creds != nil && !time.Now().Before(e.creds.Expiration) {
+			stats.Stale++
+		}
+		e.mu.Unlock()
+	}
+	return stats
+}
+
+// MinRemainingTTL returns the smallest time-until-expiration across all
+// cached entries, or zero if the cache is empty. It's exposed so a metrics
+// exporter can report how close the cache is to forcing a synchronous
+// refresh on the next caller.
+func (c *Cache) MinRemainingTTL() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var min time.Duration
+	first := true
+	for _, e := range c.entries {
+		e.mu.Lock()
+		creds := e.creds
+		e.mu.Unlock()
+		if creds == nil {
+			continue
+		}
+		ttl := time.Until(creds.Expiration)
+		if ttl < 0 {
+			ttl = 0
+		}
+		if first || ttl < min {
+			min = ttl
+			first = false
+		}
+	}
+	return min
+}
@@ -0,0 +1,170 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/netflix/weep/pkg/aws"
+)
+
+func TestGetCoalescesConcurrentMisses(t *testing.T) {
+	c := New(time.Minute)
+
+	var calls int32
+	fetch := func(ctx context.Context) (*aws.Credentials, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(10 * time.Millisecond)
+		return &aws.Credentials{RoleArn: "role", Expiration: time.Now().Add(time.Hour)}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := c.Get(context.Background(), "role", fetch); err != nil {
+				t.Errorf("Get returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected fetch to be called exactly once for concurrent misses, got %d", got)
+	}
+}
+
+func TestGetServesStaleCredentialsOnFetchError(t *testing.T) {
+	c := New(time.Minute)
+
+	goodCreds := &aws.Credentials{RoleArn: "role", Expiration: time.Now().Add(time.Hour)}
+	fail := false
+	fetch := func(ctx context.Context) (*aws.Credentials, error) {
+		if fail {
+			return nil, fmt.Errorf("consoleme is down")
+		}
+		return goodCreds, nil
+	}
+
+	got, err := c.Get(context.Background(), "role", fetch)
+	if err != nil {
+		t.Fatalf("initial Get returned error: %v", err)
+	}
+	if got != goodCreds {
+		t.Fatalf("expected initial fetch result to be cached")
+	}
+
+	// Force a cache miss on the still-warm entry by dropping it within the
+	// refresh threshold (but not truly expired), then make the next fetch
+	// fail: Get should return the stale value instead of propagating the
+	// error. Go through store (as the real refresh path does) rather than
+	// mutating the cached *aws.Credentials in place, since the latter races
+	// with scheduleRefresh's background goroutine reading that same value.
+	c.store("role", &aws.Credentials{RoleArn: "role", Expiration: time.Now().Add(30 * time.Second)}, fetch)
+
+	fail = true
+	got, err = c.Get(context.Background(), "role", fetch)
+	if err != nil {
+		t.Fatalf("expected stale credentials to be served on fetch error, got error: %v", err)
+	}
+	if got.RoleArn != "role" {
+		t.Fatalf("expected stale credentials to be returned, got %+v", got)
+	}
+}
+
+func TestGetForcesRefreshWithinThreshold(t *testing.T) {
+	c := New(time.Minute)
+
+	var calls int32
+	fetch := func(ctx context.Context) (*aws.Credentials, error) {
+		n := atomic.AddInt32(&calls, 1)
+		return &aws.Credentials{RoleArn: fmt.Sprintf("role-%d", n), Expiration: time.Now().Add(time.Hour)}, nil
+	}
+
+	if _, err := c.Get(context.Background(), "role", fetch); err != nil {
+		t.Fatalf("initial Get returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Fatalf("expected 1 fetch after initial miss, got %d", got)
+	}
+
+	// Leave the entry with less validity than the refresh threshold: Get
+	// should treat this as a miss rather than a hit, even though the
+	// credentials haven't technically expired yet. Go through store (as the
+	// real refresh path does) rather than mutating the cached
+	// *aws.Credentials in place, since the latter races with
+	// scheduleRefresh's background goroutine reading that same value.
+	c.store("role", &aws.Credentials{RoleArn: "role", Expiration: time.Now().Add(30 * time.Second)}, fetch)
+
+	if _, err := c.Get(context.Background(), "role", fetch); err != nil {
+		t.Fatalf("Get within threshold returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Fatalf("expected Get to force a refresh once remaining TTL is within the threshold, got %d fetch calls", got)
+	}
+}
+
+func TestInvalidateRemovesEntry(t *testing.T) {
+	c := New(time.Minute)
+	fetch := func(ctx context.Context) (*aws.Credentials, error) {
+		return &aws.Credentials{RoleArn: "role", Expiration: time.Now().Add(time.Hour)}, nil
+	}
+
+	if _, err := c.Get(context.Background(), "role", fetch); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if stats := c.Stats(); stats.Entries != 1 {
+		t.Fatalf("expected 1 cache entry, got %d", stats.Entries)
+	}
+
+	c.Invalidate("role")
+	if stats := c.Stats(); stats.Entries != 0 {
+		t.Fatalf("expected Invalidate to remove the entry, got %d remaining", stats.Entries)
+	}
+}
+
+func TestMinRemainingTTL(t *testing.T) {
+	c := New(time.Minute)
+
+	if ttl := c.MinRemainingTTL(); ttl != 0 {
+		t.Fatalf("expected 0 for an empty cache, got %s", ttl)
+	}
+
+	soon := func(ctx context.Context) (*aws.Credentials, error) {
+		return &aws.Credentials{RoleArn: "soon", Expiration: time.Now().Add(time.Minute)}, nil
+	}
+	later := func(ctx context.Context) (*aws.Credentials, error) {
+		return &aws.Credentials{RoleArn: "later", Expiration: time.Now().Add(time.Hour)}, nil
+	}
+	if _, err := c.Get(context.Background(), "soon", soon); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+	if _, err := c.Get(context.Background(), "later", later); err != nil {
+		t.Fatalf("Get returned error: %v", err)
+	}
+
+	ttl := c.MinRemainingTTL()
+	if ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("expected MinRemainingTTL to reflect the soonest-expiring entry, got %s", ttl)
+	}
+}
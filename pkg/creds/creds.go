@@ -0,0 +1,144 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package creds
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netflix/weep/pkg/aws"
+	"github.com/netflix/weep/pkg/creds/cache"
+
+	"github.com/spf13/viper"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const defaultProviderName = "consoleme"
+
+var (
+	credCacheOnce sync.Once
+	credCache     *cache.Cache
+)
+
+// credentialsCache lazily constructs the process-wide credential cache, sized
+// from credentials.refresh_threshold (default cache.DefaultRefreshThreshold).
+// It's a singleton, rather than threaded through every caller, since
+// GetCredentials/GetCredentialsC are already the package's public seam for
+// fetching credentials.
+func credentialsCache() *cache.Cache {
+	credCacheOnce.Do(func() {
+		threshold := viper.GetDuration("credentials.refresh_threshold")
+		credCache = cache.New(threshold)
+	})
+	return credCache
+}
+
+// cacheKey builds the cache key GetCredentialsC caches credentials under. It
+// must capture everything that affects the returned credentials: the role,
+// whether they're IP-restricted, and the chain of roles assumed on top.
+func cacheKey(role string, ipRestrict bool, assumeRole []string) string {
+	return fmt.Sprintf("%s|%t|%s", role, ipRestrict, strings.Join(assumeRole, ","))
+}
+
+// InvalidateCache drops any cached credentials for role (across all
+// ipRestrict/assumeRole variants are not tracked individually; callers that
+// need a specific variant invalidated should use the same key shape as
+// cacheKey). It's exposed for the admin endpoints.
+func InvalidateCache(role string, ipRestrict bool, assumeRole []string) {
+	credentialsCache().Invalidate(cacheKey(role, ipRestrict, assumeRole))
+}
+
+// CacheStats reports the current size of the credential cache, for the admin
+// endpoints.
+func CacheStats() cache.Stats {
+	return credentialsCache().Stats()
+}
+
+// CacheMinRemainingTTL reports the smallest time-until-expiration across all
+// cached entries, for the weep_consoleme_cached_credentials_ttl_seconds gauge.
+func CacheMinRemainingTTL() time.Duration {
+	return credentialsCache().MinRemainingTTL()
+}
+
+// GetClient resolves the configured credentials.provider (defaulting to
+// "consoleme" for backwards compatibility) and constructs it. Callers that
+// previously depended on the concrete ConsoleMe *Client should use the
+// provider/consoleme package directly; everything else should be written
+// against CredentialProvider.
+func GetClient(region string) (CredentialProvider, error) {
+	name := viper.GetString("credentials.provider")
+	if name == "" {
+		name = defaultProviderName
+	}
+	return newProvider(name, region)
+}
+
+// GetCredentialsC requests credentials from provider then follows the
+// provided chain of roles to assume. Roles are assumed in the order in which
+// they appear in the assumeRole slice. Assume-role chaining is provider
+// agnostic, so it lives here rather than in any one provider implementation.
+//
+// Results are served from the in-process credential cache when possible, so
+// that weep serve/IMDS/ECS handlers don't hit the provider on every SDK call
+// made by a workload. ctx should carry the trace context of the inbound SDK
+// call, if any, so a cache miss's ConsoleMe request shows up as a child span;
+// it's ignored on a cache hit and the background refresh goroutine always
+// starts its own detached context.
+func GetCredentialsC(ctx context.Context, provider CredentialProvider, role string, ipRestrict bool, assumeRole []string) (*aws.Credentials, error) {
+	key := cacheKey(role, ipRestrict, assumeRole)
+	return credentialsCache().Get(ctx, key, func(ctx context.Context) (*aws.Credentials, error) {
+		return fetchCredentials(ctx, provider, role, ipRestrict, assumeRole)
+	})
+}
+
+// fetchCredentials requests credentials from provider then assumes the given
+// chain of roles. This is the uncached path; it's what the credential cache
+// calls on a miss or to proactively refresh an entry in the background.
+func fetchCredentials(ctx context.Context, provider CredentialProvider, role string, ipRestrict bool, assumeRole []string) (*aws.Credentials, error) {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("weep.assume_chain_length", len(assumeRole)))
+
+	resp, err := provider.GetRoleCredentials(ctx, role, RoleCredentialsOptions{IPRestrict: ipRestrict})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, assumeRoleArn := range assumeRole {
+		resp.AccessKeyId, resp.SecretAccessKey, resp.SessionToken, err = aws.GetAssumeRoleCredentials(resp.AccessKeyId, resp.SecretAccessKey, resp.SessionToken, assumeRoleArn)
+		if err != nil {
+			return nil, fmt.Errorf("role assumption failed for %s: %s", assumeRoleArn, err)
+		}
+	}
+
+	return resp, nil
+}
+
+// GetCredentials requests credentials from the configured provider then
+// follows the provided chain of roles to assume. Roles are assumed in the
+// order in which they appear in the assumeRole slice.
+func GetCredentials(ctx context.Context, role string, ipRestrict bool, assumeRole []string, region string) (*aws.Credentials, error) {
+	provider, err := GetClient(region)
+	if err != nil {
+		return nil, err
+	}
+
+	return GetCredentialsC(ctx, provider, role, ipRestrict, assumeRole)
+}
@@ -0,0 +1,104 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package creds defines the CredentialProvider seam weep uses to fetch AWS
+// credentials. Concrete providers (ConsoleMe, STS, ...) live in subpackages
+// under pkg/creds/provider and register themselves here by name so they can
+// be selected at runtime via the credentials.provider config value.
+package creds
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/netflix/weep/pkg/aws"
+)
+
+// Role describes an assumable role as reported by a credential provider.
+type Role struct {
+	Arn                 string
+	AccountID           string
+	AccountFriendlyName string
+	RoleName            string
+}
+
+// AccountDetails describes an AWS account as reported by a credential provider.
+type AccountDetails struct {
+	AccountName   string
+	AccountNumber string
+}
+
+// RoleCredentialsOptions carries the per-request options GetRoleCredentials
+// callers can set. It is a struct, rather than positional bool args, so new
+// options can be added without breaking CredentialProvider implementations.
+type RoleCredentialsOptions struct {
+	// IPRestrict requests credentials restricted to the caller's IP, when
+	// the provider supports it.
+	IPRestrict bool
+}
+
+// CredentialProvider is implemented by every weep credential backend
+// (ConsoleMe, Vault, a plain STS assume-role provider, an internal HTTP
+// broker, ...). The metadata server and IMDS handler are written against
+// this interface so they work unmodified regardless of which provider is
+// configured.
+type CredentialProvider interface {
+	// ListRoles returns all roles the caller is eligible to assume.
+	ListRoles(ctx context.Context) ([]Role, error)
+	// GetRoleCredentials returns temporary AWS credentials for role.
+	GetRoleCredentials(ctx context.Context, role string, opts RoleCredentialsOptions) (*aws.Credentials, error)
+	// SearchAccounts returns accounts matching query.
+	SearchAccounts(ctx context.Context, query string) ([]AccountDetails, error)
+	// SearchRoles returns roles in account matching query.
+	SearchRoles(ctx context.Context, account string, query string) ([]Role, error)
+	// ResourceURL returns a browsable URL for arn.
+	ResourceURL(ctx context.Context, arn string) (string, error)
+}
+
+// ProviderFactory constructs a CredentialProvider. region is passed through
+// from the caller (e.g. CLI flag) and may be ignored by providers that don't
+// need it.
+type ProviderFactory func(region string) (CredentialProvider, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ProviderFactory{}
+)
+
+// RegisterProvider registers factory under name so it can be selected via
+// the credentials.provider config value. Providers call this from an init()
+// function in their package. Registering the same name twice is a coding
+// error and panics, matching the pattern used by database/sql drivers.
+func RegisterProvider(name string, factory ProviderFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("creds: provider %q already registered", name))
+	}
+	registry[name] = factory
+}
+
+// newProvider constructs the named provider. Callers must hold no lock.
+func newProvider(name string, region string) (CredentialProvider, error) {
+	registryMu.RLock()
+	factory, ok := registry[name]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("creds: unknown provider %q (is its package blank-imported?)", name)
+	}
+	return factory(region)
+}
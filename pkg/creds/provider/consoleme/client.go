@@ -14,10 +14,14 @@
  * limitations under the License.
  */
 
-package creds
+// Package consoleme implements the creds.CredentialProvider interface on top
+// of ConsoleMe's REST API. It is the original, and default, weep credential
+// provider.
+package consoleme
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -37,6 +41,7 @@ import (
 
 	"github.com/netflix/weep/pkg/aws"
 	"github.com/netflix/weep/pkg/config"
+	"github.com/netflix/weep/pkg/creds"
 	werrors "github.com/netflix/weep/pkg/errors"
 	"github.com/netflix/weep/pkg/httpAuth/challenge"
 	"github.com/netflix/weep/pkg/logging"
@@ -44,9 +49,31 @@ import (
 
 	"github.com/spf13/viper"
 
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+
 	"github.com/pkg/errors"
 )
 
+// providerName is the value of credentials.provider that selects this
+// provider; it is also the historical default, so existing configs that
+// don't set credentials.provider keep working unchanged.
+const providerName = "consoleme"
+
+func init() {
+	creds.RegisterProvider(providerName, newProviderForConfiguredTransport)
+}
+
+// newProviderForConfiguredTransport selects between the REST and gRPC
+// ConsoleMe transports based on the consoleme_transport config value
+// ("rest", the default, or "grpc").
+func newProviderForConfiguredTransport(region string) (creds.CredentialProvider, error) {
+	if viper.GetString("consoleme_transport") == "grpc" {
+		return NewGRPCClient(viper.GetString("consoleme_url"))
+	}
+	return GetClient()
+}
+
 var clientVersion = fmt.Sprintf("%s", metadata.Version)
 
 var userAgent = "weep/" + clientVersion + " Go-http-client/1.1"
@@ -54,7 +81,7 @@ var userAgent = "weep/" + clientVersion + " Go-http-client/1.1"
 // HTTPClient is the interface we expect HTTP clients to implement.
 type HTTPClient interface {
 	Do(req *http.Request) (*http.Response, error)
-	GetRoleCredentials(role string, ipRestrict bool) (*aws.Credentials, error)
+	getRoleCredentials(ctx context.Context, role string, ipRestrict bool) (*aws.Credentials, error)
 	CloseIdleConnections()
 	buildRequest(string, string, io.Reader, string) (*http.Request, error)
 }
@@ -64,6 +91,12 @@ type Client struct {
 	http.Client
 	Host   string
 	Region string
+
+	// baseTransport is the innermost *http.Transport NewClient wrapped in
+	// retry/OpenTelemetry instrumentation (nil if the caller supplied a
+	// transport that isn't a bare *http.Transport), kept around so
+	// CloseIdleConnections can still reach it once it's wrapped.
+	baseTransport *http.Transport
 }
 
 type Role struct {
@@ -92,20 +125,35 @@ func GetClient() (*Client, error) {
 }
 
 // NewClient takes a ConsoleMe hostname and *http.Client, and returns a
-// ConsoleMe client that will talk to that ConsoleMe instance for AWS Credentials.
+// ConsoleMe client that will talk to that ConsoleMe instance for AWS
+// Credentials. The retry and OpenTelemetry instrumentation transports are
+// wrapped around httpc's transport unconditionally, so callers that pass
+// their own *http.Client (e.g. GetClient, which passes the authenticated
+// client from httpAuth.GetAuthenticatedClient) still get retries and spans,
+// not just callers that leave httpc nil.
 func NewClient(hostname string, region string, httpc *http.Client) (*Client, error) {
 	if len(hostname) == 0 {
 		return nil, errors.New("hostname cannot be empty string")
 	}
 
 	if httpc == nil {
-		httpc = &http.Client{Transport: defaultTransport()}
+		httpc = &http.Client{}
 	}
 
+	innerTransport := httpc.Transport
+	if innerTransport == nil {
+		innerTransport = newBaseTransport()
+	}
+	baseTransport, _ := innerTransport.(*http.Transport)
+
+	wrapped := *httpc
+	wrapped.Transport = instrumentTransport(newRetryTransport(innerTransport))
+
 	c := &Client{
-		Client: *httpc,
-		Host:   hostname,
-		Region: region,
+		Client:        wrapped,
+		Host:          hostname,
+		Region:        region,
+		baseTransport: baseTransport,
 	}
 
 	return c, nil
@@ -127,18 +175,24 @@ func (c *Client) buildRequest(method string, resource string, body io.Reader, ap
 	return req, nil
 }
 
-// CloseIdleConnections calls CloseIdleConnections() on the client's HTTP transport.
+// CloseIdleConnections calls CloseIdleConnections() on the client's
+// underlying *http.Transport via baseTransport, since c.Client.Transport
+// itself is always wrapped in retry and OpenTelemetry instrumentation layers
+// that don't expose the transport they wrap. baseTransport is only populated
+// when that underlying transport is a bare *http.Transport; otherwise this is
+// a no-op, since there's no portable way to reach an idle-connection pool
+// behind an arbitrary caller-supplied http.RoundTripper.
 func (c *Client) CloseIdleConnections() {
-	transport, ok := c.Client.Transport.(*http.Transport)
-	if !ok {
-		// This is unlikely, but we'll fail out anyway.
-		return
+	if c.baseTransport != nil {
+		c.baseTransport.CloseIdleConnections()
 	}
-	transport.CloseIdleConnections()
 }
 
 // Roles returns all eligible role ARNs, using v1 of eligible roles endpoint
-func (c *Client) Roles() ([]Role, error) {
+func (c *Client) Roles(ctx context.Context) (_ []Role, err error) {
+	ctx, finish := requestSpan(ctx, "Roles")
+	defer finish(&err)
+
 	req, err := c.buildRequest(http.MethodGet, "/get_roles", nil, "/api/v2")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build request")
@@ -149,7 +203,7 @@ func (c *Client) Roles() ([]Role, error) {
 	q.Add("all", "true")
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to action request")
 	}
@@ -172,7 +226,10 @@ func (c *Client) Roles() ([]Role, error) {
 }
 
 // RolesExtended returns all eligible role along with additional details, using v2 of eligible roles endpoint
-func (c *Client) RolesExtended() ([]ConsolemeRolesResponse, error) {
+func (c *Client) RolesExtended(ctx context.Context) (_ []ConsolemeRolesResponse, err error) {
+	ctx, finish := requestSpan(ctx, "RolesExtended")
+	defer finish(&err)
+
 	req, err := c.buildRequest(http.MethodGet, "/get_roles", nil, "/api/v2")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build request")
@@ -183,7 +240,7 @@ func (c *Client) RolesExtended() ([]ConsolemeRolesResponse, error) {
 	q.Add("all", "true")
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to action request")
 	}
@@ -210,7 +267,10 @@ func (c *Client) RolesExtended() ([]ConsolemeRolesResponse, error) {
 }
 
 // GetResourceURL gets resource URL from ConsoleMe given an ARN
-func (c *Client) GetResourceURL(arn string) (string, error) {
+func (c *Client) GetResourceURL(ctx context.Context, arn string) (_ string, err error) {
+	ctx, finish := requestSpan(ctx, "GetResourceURL", attribute.String("weep.arn", arn))
+	defer finish(&err)
+
 	req, err := c.buildRequest(http.MethodGet, "/get_resource_url", nil, "/api/v2")
 	if err != nil {
 		return "", errors.Wrap(err, "failed to build request")
@@ -221,7 +281,7 @@ func (c *Client) GetResourceURL(arn string) (string, error) {
 	q.Add("arn", arn)
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return "", errors.Wrap(err, "failed to action request")
 	}
@@ -232,7 +292,7 @@ func (c *Client) GetResourceURL(arn string) (string, error) {
 		return "", errors.Wrap(err, "failed to read response body")
 	}
 	if resp.StatusCode != http.StatusOK {
-		return "", parseWebError(document)
+		return "", parseWebError(resp.StatusCode, document)
 	}
 	var responseParsed ConsolemeWebResponse
 	if err := json.Unmarshal(document, &responseParsed); err != nil {
@@ -246,22 +306,25 @@ func (c *Client) GetResourceURL(arn string) (string, error) {
 }
 
 // GenericGet makes a GET request to the request URL
-func (c *Client) GenericGet(resource string, apiPrefix string) (map[string]json.RawMessage, error) {
-	return c.genericRequest(http.MethodGet, resource, apiPrefix, nil)
+func (c *Client) GenericGet(ctx context.Context, resource string, apiPrefix string) (map[string]json.RawMessage, error) {
+	return c.genericRequest(ctx, http.MethodGet, resource, apiPrefix, nil)
 }
 
 // GenericPost makes a POST request to the request URL
-func (c *Client) GenericPost(resource string, apiPrefix string, b *bytes.Buffer) (map[string]json.RawMessage, error) {
-	return c.genericRequest(http.MethodPost, resource, apiPrefix, b)
+func (c *Client) GenericPost(ctx context.Context, resource string, apiPrefix string, b *bytes.Buffer) (map[string]json.RawMessage, error) {
+	return c.genericRequest(ctx, http.MethodPost, resource, apiPrefix, b)
 }
 
-func (c *Client) genericRequest(method string, resource string, apiPrefix string, b io.Reader) (map[string]json.RawMessage, error) {
+func (c *Client) genericRequest(ctx context.Context, method string, resource string, apiPrefix string, b io.Reader) (_ map[string]json.RawMessage, err error) {
+	ctx, finish := requestSpan(ctx, "genericRequest", attribute.String("weep.resource", resource))
+	defer finish(&err)
+
 	req, err := c.buildRequest(method, resource, b, apiPrefix)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build request")
 	}
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to action request")
 	}
@@ -272,7 +335,7 @@ func (c *Client) genericRequest(method string, resource string, apiPrefix string
 		return nil, errors.Wrap(err, "failed to read response body")
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseWebError(document)
+		return nil, parseWebError(resp.StatusCode, document)
 	}
 	var responseParsed ConsolemeWebResponse
 	if err := json.Unmarshal(document, &responseParsed); err != nil {
@@ -282,53 +345,62 @@ func (c *Client) genericRequest(method string, resource string, apiPrefix string
 	return responseParsed.Data, nil
 }
 
-func parseWebError(rawErrorResponse []byte) error {
+func parseWebError(statusCode int, rawErrorResponse []byte) error {
 	var errorResponse ConsolemeWebResponse
 	if err := json.Unmarshal(rawErrorResponse, &errorResponse); err != nil {
 		return errors.Wrap(err, "failed to unmarshal JSON")
 	}
-	return fmt.Errorf(strings.Join(errorResponse.Errors, "\n"))
+	return werrors.NewConsoleMeError("", strings.Join(errorResponse.Errors, "\n"), statusCode, errorResponse.RequestID, nil)
 }
 
+// parseError maps a ConsoleMe error response to a *werrors.ConsoleMeError.
+// The returned error still matches the historical sentinels (InvalidArn,
+// NoMatchingRoles, etc.) via errors.Is, but also carries the original
+// message, HTTP status, and request ID so callers can render actionable
+// messages and branch on .Code for retry decisions.
 func parseError(statusCode int, rawErrorResponse []byte) error {
 	var errorResponse ConsolemeCredentialErrorMessageType
 	if err := json.Unmarshal(rawErrorResponse, &errorResponse); err != nil {
 		return errors.Wrap(err, "failed to unmarshal JSON")
 	}
 
+	newErr := func(cause error) error {
+		return werrors.NewConsoleMeError(errorResponse.Code, errorResponse.Message, statusCode, errorResponse.RequestID, cause)
+	}
+
 	switch errorResponse.Code {
 	case "899":
-		return werrors.InvalidArn
+		return newErr(werrors.InvalidArn)
 	case "900":
-		return werrors.NoMatchingRoles
+		return newErr(werrors.NoMatchingRoles)
 	case "901":
-		return werrors.MultipleMatchingRoles
+		return newErr(werrors.MultipleMatchingRoles)
 	case "902":
-		return werrors.CredentialRetrievalError
+		return newErr(werrors.CredentialRetrievalError)
 	case "903":
-		return werrors.NoMatchingRoles
+		return newErr(werrors.NoMatchingRoles)
 	case "904":
-		return werrors.MalformedRequestError
+		return newErr(werrors.MalformedRequestError)
 	case "905":
-		return werrors.MutualTLSCertNeedsRefreshError
+		return newErr(werrors.MutualTLSCertNeedsRefreshError)
 	case "invalid_jwt":
 		logging.Log.Errorf("Authentication is invalid or has expired. Please restart weep to re-authenticate.")
 		err := challenge.DeleteLocalWeepCredentials()
 		if err != nil {
 			logging.Log.Errorf("failed to delete credentials: %v", err)
 		}
-		return werrors.InvalidJWT
+		return newErr(werrors.InvalidJWT)
 	default:
-		return fmt.Errorf("unexpected HTTP status %d, want 200. Response: %s", statusCode, string(rawErrorResponse))
+		return werrors.NewConsoleMeError(errorResponse.Code, fmt.Sprintf("unexpected HTTP status %d, want 200. Response: %s", statusCode, string(rawErrorResponse)), statusCode, errorResponse.RequestID, nil)
 	}
 }
 
-func (c *Client) GetRoleCredentials(role string, ipRestrict bool) (*aws.Credentials, error) {
-	return getRoleCredentialsFunc(c, role, ipRestrict)
+func (c *Client) getRoleCredentials(ctx context.Context, role string, ipRestrict bool) (*aws.Credentials, error) {
+	return getRoleCredentialsFunc(ctx, c, role, ipRestrict)
 }
 
-func (c *Client) GetAccounts(query string) ([]ConsolemeAccountDetails, error) {
-	resp, err := c.searchResources("account", query, 1000)
+func (c *Client) GetAccounts(ctx context.Context, query string) ([]ConsolemeAccountDetails, error) {
+	resp, err := c.searchResources(ctx, "account", query, 1000)
 	if err != nil {
 		return nil, err
 	}
@@ -342,9 +414,9 @@ func (c *Client) GetAccounts(query string) ([]ConsolemeAccountDetails, error) {
 	return accounts, nil
 }
 
-func (c *Client) GetRolesInAccount(query string, accountNumber string) ([]ConsolemeRolesResponse, error) {
+func (c *Client) GetRolesInAccount(ctx context.Context, query string, accountNumber string) ([]ConsolemeRolesResponse, error) {
 	query = "arn:aws:iam::" + accountNumber + ":role/" + query
-	resp, err := c.searchResources("iam_arn", query, 5000)
+	resp, err := c.searchResources(ctx, "iam_arn", query, 5000)
 	if err != nil {
 		return nil, err
 	}
@@ -356,7 +428,10 @@ func (c *Client) GetRolesInAccount(query string, accountNumber string) ([]Consol
 	return roles, nil
 }
 
-func (c *Client) searchResources(resourceType string, query string, limit int) ([]ConsolemeResourceSearchResponseElement, error) {
+func (c *Client) searchResources(ctx context.Context, resourceType string, query string, limit int) (_ []ConsolemeResourceSearchResponseElement, err error) {
+	ctx, finish := requestSpan(ctx, "searchResources", attribute.String("weep.resource_type", resourceType))
+	defer finish(&err)
+
 	req, err := c.buildRequest(http.MethodGet, "/policies/typeahead", nil, "/api/v1")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to build request")
@@ -369,7 +444,7 @@ func (c *Client) searchResources(resourceType string, query string, limit int) (
 	q.Add("limit", strconv.Itoa(limit))
 	req.URL.RawQuery = q.Encode()
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to action request")
 	}
@@ -380,7 +455,7 @@ func (c *Client) searchResources(resourceType string, query string, limit int) (
 		return nil, errors.Wrap(err, "failed to read response body")
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, parseWebError(document)
+		return nil, parseWebError(resp.StatusCode, document)
 	}
 
 	var responseParsed []ConsolemeResourceSearchResponseElement
@@ -391,7 +466,13 @@ func (c *Client) searchResources(resourceType string, query string, limit int) (
 	return responseParsed, nil
 }
 
-func getRoleCredentialsFunc(c HTTPClient, role string, ipRestrict bool) (*aws.Credentials, error) {
+func getRoleCredentialsFunc(ctx context.Context, c HTTPClient, role string, ipRestrict bool) (_ *aws.Credentials, err error) {
+	ctx, finish := requestSpan(ctx, "GetRoleCredentials",
+		attribute.String("weep.role", role),
+		attribute.Bool("weep.ip_restrict", ipRestrict),
+	)
+	defer finish(&err)
+
 	var credentialsResponse ConsolemeCredentialResponseType
 
 	cmCredRequest := ConsolemeCredentialRequestType{
@@ -404,7 +485,7 @@ func getRoleCredentialsFunc(c HTTPClient, role string, ipRestrict bool) (*aws.Cr
 	}
 
 	b := new(bytes.Buffer)
-	err := json.NewEncoder(b).Encode(cmCredRequest)
+	err = json.NewEncoder(b).Encode(cmCredRequest)
 	if err != nil {
 		return credentialsResponse.Credentials, errors.Wrap(err, "failed to create request body")
 	}
@@ -414,7 +495,7 @@ func getRoleCredentialsFunc(c HTTPClient, role string, ipRestrict bool) (*aws.Cr
 		return credentialsResponse.Credentials, errors.Wrap(err, "failed to build request")
 	}
 
-	resp, err := c.Do(req)
+	resp, err := c.Do(req.WithContext(ctx))
 	if err != nil {
 		return credentialsResponse.Credentials, errors.Wrap(err, "failed to action request")
 	}
@@ -439,7 +520,7 @@ func getRoleCredentialsFunc(c HTTPClient, role string, ipRestrict bool) (*aws.Cr
 	return credentialsResponse.Credentials, nil
 }
 
-func defaultTransport() *http.Transport {
+func newBaseTransport() *http.Transport {
 	timeout := time.Duration(viper.GetInt("server.http_timeout")) * time.Second
 	return &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
@@ -455,13 +536,20 @@ func defaultTransport() *http.Transport {
 	}
 }
 
+// instrumentTransport wraps next with otelhttp so every outbound ConsoleMe
+// request automatically gets a span (with URL, status, and duration) in
+// addition to the request-level spans requestSpan creates.
+func instrumentTransport(next http.RoundTripper) http.RoundTripper {
+	return otelhttp.NewTransport(next)
+}
+
 type ClientMock struct {
 	DoFunc                 func(req *http.Request) (*http.Response, error)
-	GetRoleCredentialsFunc func(role string, ipRestrict bool) (*aws.Credentials, error)
+	GetRoleCredentialsFunc func(ctx context.Context, role string, ipRestrict bool) (*aws.Credentials, error)
 }
 
-func (c *ClientMock) GetRoleCredentials(role string, ipRestrict bool) (*aws.Credentials, error) {
-	return getRoleCredentialsFunc(c, role, ipRestrict)
+func (c *ClientMock) getRoleCredentials(ctx context.Context, role string, ipRestrict bool) (*aws.Credentials, error) {
+	return getRoleCredentialsFunc(ctx, c, role, ipRestrict)
 }
 
 func (c *ClientMock) CloseIdleConnections() {}
@@ -499,7 +587,7 @@ func GetTestClient(responseBody interface{}) (HTTPClient, error) {
 				Body:       r,
 			}, nil
 		},
-		GetRoleCredentialsFunc: func(role string, ipRestrict bool) (*aws.Credentials, error) {
+		GetRoleCredentialsFunc: func(ctx context.Context, role string, ipRestrict bool) (*aws.Credentials, error) {
 			if responseCredentials != nil {
 				return responseCredentials, nil
 			}
@@ -509,32 +597,56 @@ func GetTestClient(responseBody interface{}) (HTTPClient, error) {
 	return client, nil
 }
 
-// GetCredentialsC uses the provided Client to request credentials from ConsoleMe then
-// follows the provided chain of roles to assume. Roles are assumed in the order in which
-// they appear in the assumeRole slice.
-func GetCredentialsC(client HTTPClient, role string, ipRestrict bool, assumeRole []string) (*aws.Credentials, error) {
-	resp, err := client.GetRoleCredentials(role, ipRestrict)
+// ListRoles implements creds.CredentialProvider by delegating to Roles.
+func (c *Client) ListRoles(ctx context.Context) ([]creds.Role, error) {
+	roles, err := c.Roles(ctx)
 	if err != nil {
 		return nil, err
 	}
-
-	for _, assumeRoleArn := range assumeRole {
-		resp.AccessKeyId, resp.SecretAccessKey, resp.SessionToken, err = aws.GetAssumeRoleCredentials(resp.AccessKeyId, resp.SecretAccessKey, resp.SessionToken, assumeRoleArn)
-		if err != nil {
-			return nil, fmt.Errorf("role assumption failed for %s: %s", assumeRoleArn, err)
-		}
+	out := make([]creds.Role, 0, len(roles))
+	for _, r := range roles {
+		out = append(out, creds.Role{
+			Arn:                 r.Arn,
+			AccountID:           r.AccountId,
+			AccountFriendlyName: r.AccountFriendlyName,
+			RoleName:            r.RoleName,
+		})
 	}
+	return out, nil
+}
 
-	return resp, nil
+// GetRoleCredentials implements creds.CredentialProvider by delegating to getRoleCredentialsFunc.
+func (c *Client) GetRoleCredentials(ctx context.Context, role string, opts creds.RoleCredentialsOptions) (*aws.Credentials, error) {
+	return getRoleCredentialsFunc(ctx, c, role, opts.IPRestrict)
 }
 
-// GetCredentials requests credentials from ConsoleMe then follows the provided chain of roles to
-// assume. Roles are assumed in the order in which they appear in the assumeRole slice.
-func GetCredentials(role string, ipRestrict bool, assumeRole []string, region string) (*aws.Credentials, error) {
-	client, err := GetClient()
+// SearchAccounts implements creds.CredentialProvider by delegating to GetAccounts.
+func (c *Client) SearchAccounts(ctx context.Context, query string) ([]creds.AccountDetails, error) {
+	accounts, err := c.GetAccounts(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	out := make([]creds.AccountDetails, 0, len(accounts))
+	for _, a := range accounts {
+		out = append(out, creds.AccountDetails{AccountName: a.AccountName, AccountNumber: a.AccountNumber})
+	}
+	return out, nil
+}
+
+// SearchRoles implements creds.CredentialProvider by delegating to GetRolesInAccount.
+func (c *Client) SearchRoles(ctx context.Context, account string, query string) ([]creds.Role, error) {
+	roles, err := c.GetRolesInAccount(ctx, query, account)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]creds.Role, 0, len(roles))
+	for _, r := range roles {
+		out = append(out, creds.Role{Arn: r.Arn, RoleName: r.RoleName})
+	}
+	return out, nil
+}
 
-	return GetCredentialsC(client, role, ipRestrict, assumeRole)
+// ResourceURL implements creds.CredentialProvider by delegating to GetResourceURL.
+func (c *Client) ResourceURL(ctx context.Context, arn string) (string, error) {
+	return c.GetResourceURL(ctx, arn)
 }
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consoleme
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/netflix/weep/pkg/aws"
+	"github.com/netflix/weep/pkg/creds"
+	weepgrpc "github.com/netflix/weep/pkg/creds/provider/consoleme/grpc"
+	werrors "github.com/netflix/weep/pkg/errors"
+	"github.com/netflix/weep/pkg/httpAuth/challenge"
+	"github.com/netflix/weep/pkg/httpAuth/mutualtls"
+)
+
+// GRPCClient is a gRPC-backed ConsoleMe client. It implements the same
+// creds.CredentialProvider seam as Client, so `weep serve` deployments can
+// switch transports (consoleme_transport: rest|grpc) without touching
+// anything above pkg/creds.
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client weepgrpc.ConsoleMeClient
+}
+
+// NewGRPCClient dials hostname over gRPC, authenticating each RPC with the
+// same bearer JWT (or mTLS cert) the REST client uses.
+func NewGRPCClient(hostname string) (*GRPCClient, error) {
+	tlsConfig, err := mutualtls.ClientTLSConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)),
+		grpc.WithPerRPCCredentials(&jwtPerRPCCredentials{}),
+	}
+
+	conn, err := grpc.Dial(hostname, dialOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GRPCClient{conn: conn, client: weepgrpc.NewConsoleMeClient(conn)}, nil
+}
+
+// Close tears down the underlying gRPC connection.
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *GRPCClient) ListRoles(ctx context.Context) ([]creds.Role, error) {
+	resp, err := c.client.Roles(ctx, &weepgrpc.RolesRequest{All: true})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]creds.Role, 0, len(resp.Roles))
+	for _, r := range resp.Roles {
+		out = append(out, creds.Role{
+			Arn:                 r.Arn,
+			AccountID:           r.AccountId,
+			AccountFriendlyName: r.AccountFriendlyName,
+			RoleName:            r.RoleName,
+		})
+	}
+	return out, nil
+}
+
+func (c *GRPCClient) GetRoleCredentials(ctx context.Context, role string, opts creds.RoleCredentialsOptions) (*aws.Credentials, error) {
+	resp, err := c.client.GetRoleCredentials(ctx, &weepgrpc.GetRoleCredentialsRequest{
+		Role:            role,
+		NoIpRestriction: opts.IPRestrict,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Credentials == nil {
+		return nil, werrors.CredentialRetrievalError
+	}
+	return &aws.Credentials{
+		RoleArn:         resp.Credentials.RoleArn,
+		AccessKeyId:     resp.Credentials.AccessKeyId,
+		SecretAccessKey: resp.Credentials.SecretAccessKey,
+		SessionToken:    resp.Credentials.SessionToken,
+		Expiration:      time.Unix(resp.Credentials.ExpirationUnix, 0),
+	}, nil
+}
+
+// SearchAccounts mirrors the REST client's GetAccounts: it searches the
+// "account" resource type and parses ConsoleMe's "name (number)" typeahead
+// title into AccountDetails.
+func (c *GRPCClient) SearchAccounts(ctx context.Context, query string) ([]creds.AccountDetails, error) {
+	stream, err := c.client.SearchResources(ctx, &weepgrpc.SearchResourcesRequest{
+		ResourceType: "account",
+		Query:        query,
+		Limit:        1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var accounts []creds.AccountDetails
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		idx := strings.Index(msg.Title, "(")
+		if idx < 1 || !strings.HasSuffix(msg.Title, ")") {
+			continue
+		}
+		accounts = append(accounts, creds.AccountDetails{
+			AccountName:   msg.Title[0 : idx-1],
+			AccountNumber: msg.Title[idx+1 : len(msg.Title)-1],
+		})
+	}
+	return accounts, nil
+}
+
+func (c *GRPCClient) SearchRoles(ctx context.Context, account string, query string) ([]creds.Role, error) {
+	stream, err := c.client.SearchResources(ctx, &weepgrpc.SearchResourcesRequest{
+		ResourceType: "iam_arn",
+		Query:        "arn:aws:iam::" + account + ":role/" + query,
+		Limit:        5000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []creds.Role
+	for {
+		msg, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		roles = append(roles, creds.Role{Arn: msg.Title})
+	}
+	return roles, nil
+}
+
+func (c *GRPCClient) ResourceURL(ctx context.Context, arn string) (string, error) {
+	resp, err := c.client.GetResourceURL(ctx, &weepgrpc.GetResourceURLRequest{Arn: arn})
+	if err != nil {
+		return "", err
+	}
+	return resp.Url, nil
+}
+
+// jwtPerRPCCredentials translates the bearer JWT obtained by the challenge
+// flow into gRPC's per-RPC credential mechanism, mirroring the
+// Authorization header the REST client attaches via custom.RunPreflightFunctions.
+type jwtPerRPCCredentials struct{}
+
+func (j *jwtPerRPCCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := challenge.GetLocalWeepJWT()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"authorization": "Bearer " + token,
+	}, nil
+}
+
+func (j *jwtPerRPCCredentials) RequireTransportSecurity() bool {
+	return true
+}
@@ -0,0 +1,257 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consoleme
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	werrors "github.com/netflix/weep/pkg/errors"
+	"github.com/netflix/weep/pkg/logging"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	defaultRetryMaxAttempts = 3
+	defaultRetryBaseDelay   = 200 * time.Millisecond
+	defaultRetryMaxDelay    = 5 * time.Second
+)
+
+// retryableMethods are retried by default, since they are safe to repeat.
+var retryableMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+}
+
+// Retry condition names recognized by server.retry.retry_on.
+const (
+	retryOnConnectionError = "connection_error"
+	retryOnTooManyRequests = "429"
+	retryOnBadGateway      = "502"
+	retryOnServiceUnavail  = "503"
+	retryOnGatewayTimeout  = "504"
+	retryOnCredentialError = "902"
+)
+
+// defaultRetryOn is the retry set used when server.retry.retry_on is unset.
+var defaultRetryOn = []string{
+	retryOnConnectionError,
+	retryOnTooManyRequests,
+	retryOnBadGateway,
+	retryOnServiceUnavail,
+	retryOnGatewayTimeout,
+	retryOnCredentialError,
+}
+
+// retryTransport wraps an http.RoundTripper with full-jitter exponential
+// backoff retries for transient ConsoleMe failures, so that `weep serve`/IMDS
+// credential refreshes in the background don't fail hard on the first
+// network blip or 5xx response.
+type retryTransport struct {
+	next http.RoundTripper
+}
+
+// newRetryTransport wraps next with the retry policy configured via viper
+// (server.retry.max_attempts, server.retry.base_delay, server.retry.max_delay,
+// server.retry.retry_on).
+func newRetryTransport(next http.RoundTripper) *retryTransport {
+	return &retryTransport{next: next}
+}
+
+func (t *retryTransport) maxAttempts() int {
+	if n := viper.GetInt("server.retry.max_attempts"); n > 0 {
+		return n
+	}
+	return defaultRetryMaxAttempts
+}
+
+func (t *retryTransport) baseDelay() time.Duration {
+	if d := viper.GetDuration("server.retry.base_delay"); d > 0 {
+		return d
+	}
+	return defaultRetryBaseDelay
+}
+
+func (t *retryTransport) maxDelay() time.Duration {
+	if d := viper.GetDuration("server.retry.max_delay"); d > 0 {
+		return d
+	}
+	return defaultRetryMaxDelay
+}
+
+// retryOn returns the set of conditions eligible for retry, configured via
+// server.retry.retry_on (e.g. ["429", "502", "902"]) and defaulting to
+// defaultRetryOn when unset.
+func (t *retryTransport) retryOn() map[string]bool {
+	values := viper.GetStringSlice("server.retry.retry_on")
+	if len(values) == 0 {
+		values = defaultRetryOn
+	}
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+// RoundTrip executes req, retrying transient failures with full-jitter
+// exponential backoff. Request bodies are buffered up front so they can be
+// replayed across attempts.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var bodyBytes []byte
+	if req.Body != nil {
+		var err error
+		bodyBytes, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.maxDelay()*time.Duration(t.maxAttempts())+30*time.Second)
+	defer cancel()
+	req = req.WithContext(ctx)
+
+	var resp *http.Response
+	var err error
+	attempts := t.maxAttempts()
+
+	for attempt := 0; attempt < attempts; attempt++ {
+		if bodyBytes != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+			req.ContentLength = int64(len(bodyBytes))
+		}
+
+		resp, err = t.next.RoundTrip(req)
+
+		reason, retry := t.shouldRetry(req, resp, err)
+		if !retry || attempt == attempts-1 {
+			return resp, err
+		}
+
+		delay := t.retryAfterDelay(resp)
+		if delay == 0 {
+			delay = fullJitterBackoff(attempt, t.baseDelay(), t.maxDelay())
+		}
+
+		logging.Log.Warnf("retrying ConsoleMe request to %s: attempt=%d delay=%s reason=%s", req.URL.Path, attempt+1, delay, reason)
+
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return resp, err
+		}
+	}
+
+	return resp, err
+}
+
+// shouldRetry reports whether the request is eligible for a retry and why.
+func (t *retryTransport) shouldRetry(req *http.Request, resp *http.Response, err error) (string, bool) {
+	if !retryableMethods[req.Method] && !(req.Method == http.MethodPost && req.URL.Path == "/api/v1/get_credentials") {
+		return "", false
+	}
+
+	retryOn := t.retryOn()
+
+	if err != nil {
+		if !retryOn[retryOnConnectionError] {
+			return "", false
+		}
+		return "connection error: " + err.Error(), true
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return httpRetryReason(retryOn, resp.StatusCode, retryOnTooManyRequests)
+	case http.StatusBadGateway:
+		return httpRetryReason(retryOn, resp.StatusCode, retryOnBadGateway)
+	case http.StatusServiceUnavailable:
+		return httpRetryReason(retryOn, resp.StatusCode, retryOnServiceUnavail)
+	case http.StatusGatewayTimeout:
+		return httpRetryReason(retryOn, resp.StatusCode, retryOnGatewayTimeout)
+	case http.StatusOK:
+		return "", false
+	}
+
+	if !retryOn[retryOnCredentialError] {
+		return "", false
+	}
+
+	// Peek the body for a ConsoleMe-coded error (e.g. 902 CredentialRetrievalError)
+	// without consuming it for the caller.
+	document, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(document))
+	if readErr != nil {
+		return "", false
+	}
+	if cmErr, ok := parseError(resp.StatusCode, document).(*werrors.ConsoleMeError); ok {
+		if errors.Is(cmErr, werrors.CredentialRetrievalError) {
+			return "consoleme code 902", true
+		}
+	}
+
+	return "", false
+}
+
+// httpRetryReason reports whether statusCode is retryable given retryOn, and
+// the log reason string to use if so.
+func httpRetryReason(retryOn map[string]bool, statusCode int, condition string) (string, bool) {
+	if !retryOn[condition] {
+		return "", false
+	}
+	return "http " + strconv.Itoa(statusCode), true
+}
+
+// retryAfterDelay honors a Retry-After header expressed in seconds, returning
+// zero if absent or unparsable so the caller falls back to backoff.
+func (t *retryTransport) retryAfterDelay(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	ra := resp.Header.Get("Retry-After")
+	if ra == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(ra); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	return 0
+}
+
+// fullJitterBackoff implements the "full jitter" backoff algorithm:
+// sleep = rand(0, min(cap, base * 2^attempt)).
+func fullJitterBackoff(attempt int, base, cap time.Duration) time.Duration {
+	backoff := base << attempt
+	if backoff <= 0 || backoff > cap {
+		backoff = cap
+	}
+	return time.Duration(rand.Int63n(int64(backoff) + 1))
+}
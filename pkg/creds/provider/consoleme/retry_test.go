@@ -0,0 +1,153 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consoleme
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func newRetryTestRequest(t *testing.T, method, path string) *http.Request {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	return req
+}
+
+func newRetryTestResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestShouldRetryDecisionMatrix(t *testing.T) {
+	tr := &retryTransport{}
+
+	cases := []struct {
+		name      string
+		method    string
+		path      string
+		resp      *http.Response
+		err       error
+		wantRetry bool
+	}{
+		{
+			name:      "GET connection error retries",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			err:       errors.New("connection reset"),
+			wantRetry: true,
+		},
+		{
+			name:      "POST connection error does not retry",
+			method:    http.MethodPost,
+			path:      "/api/v1/policies/typeahead",
+			err:       errors.New("connection reset"),
+			wantRetry: false,
+		},
+		{
+			name:      "POST to get_credentials retries on connection error",
+			method:    http.MethodPost,
+			path:      "/api/v1/get_credentials",
+			err:       errors.New("connection reset"),
+			wantRetry: true,
+		},
+		{
+			name:      "GET 200 does not retry",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusOK, "{}"),
+			wantRetry: false,
+		},
+		{
+			name:      "GET 429 retries",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusTooManyRequests, "{}"),
+			wantRetry: true,
+		},
+		{
+			name:      "GET 502 retries",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusBadGateway, "{}"),
+			wantRetry: true,
+		},
+		{
+			name:      "GET 503 retries",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusServiceUnavailable, "{}"),
+			wantRetry: true,
+		},
+		{
+			name:      "GET 504 retries",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusGatewayTimeout, "{}"),
+			wantRetry: true,
+		},
+		{
+			name:      "GET 404 does not retry",
+			method:    http.MethodGet,
+			path:      "/api/v1/get_roles",
+			resp:      newRetryTestResponse(http.StatusNotFound, `{"code":"900","message":"no matching roles"}`),
+			wantRetry: false,
+		},
+		{
+			name:      "POST get_credentials retries on consoleme code 902",
+			method:    http.MethodPost,
+			path:      "/api/v1/get_credentials",
+			resp:      newRetryTestResponse(http.StatusInternalServerError, `{"code":"902","message":"failed to retrieve credentials"}`),
+			wantRetry: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := newRetryTestRequest(t, tc.method, tc.path)
+			_, retry := tr.shouldRetry(req, tc.resp, tc.err)
+			if retry != tc.wantRetry {
+				t.Errorf("shouldRetry() = %v, want %v", retry, tc.wantRetry)
+			}
+		})
+	}
+}
+
+func TestShouldRetryHonorsRetryOn(t *testing.T) {
+	viper.Set("server.retry.retry_on", []string{"502"})
+	defer viper.Set("server.retry.retry_on", nil)
+
+	tr := &retryTransport{}
+	req := newRetryTestRequest(t, http.MethodGet, "/api/v1/get_roles")
+
+	if _, retry := tr.shouldRetry(req, newRetryTestResponse(http.StatusBadGateway, "{}"), nil); !retry {
+		t.Error("expected 502 to be retryable when retry_on=[502]")
+	}
+	if _, retry := tr.shouldRetry(req, newRetryTestResponse(http.StatusTooManyRequests, "{}"), nil); retry {
+		t.Error("expected 429 to not be retryable when retry_on=[502]")
+	}
+	if _, retry := tr.shouldRetry(req, nil, errors.New("connection reset")); retry {
+		t.Error("expected connection errors to not be retryable when retry_on=[502]")
+	}
+}
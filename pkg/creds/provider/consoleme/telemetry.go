@@ -0,0 +1,101 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package consoleme
+
+import (
+	"context"
+	"time"
+
+	"github.com/netflix/weep/pkg/creds"
+	werrors "github.com/netflix/weep/pkg/errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/netflix/weep/pkg/creds/provider/consoleme"
+
+var (
+	tracer = otel.Tracer(instrumentationName)
+	meter  = otel.Meter(instrumentationName)
+
+	requestsTotal, _ = meter.Int64Counter(
+		"weep_consoleme_requests_total",
+		metric.WithDescription("Total ConsoleMe requests made by the weep client, by endpoint and ConsoleMe error code"),
+	)
+	requestDuration, _ = meter.Float64Histogram(
+		"weep_consoleme_request_duration_seconds",
+		metric.WithDescription("ConsoleMe request duration in seconds, by endpoint"),
+		metric.WithUnit("s"),
+	)
+)
+
+func init() {
+	cachedCredentialsTTL, err := meter.Float64ObservableGauge(
+		"weep_consoleme_cached_credentials_ttl_seconds",
+		metric.WithDescription("Time remaining until the soonest-expiring cached credential set is refreshed"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return
+	}
+	_, _ = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		o.ObserveFloat64(cachedCredentialsTTL, creds.CacheMinRemainingTTL().Seconds())
+		return nil
+	}, cachedCredentialsTTL)
+}
+
+// requestSpan starts a span for an outbound ConsoleMe call named
+// "consoleme.<endpoint>" and returns a finish func that records the
+// request's duration and result, annotating both the span and the
+// weep_consoleme_requests_total/weep_consoleme_request_duration_seconds
+// instruments. Callers should defer finish(&err) using the named error
+// return of the instrumented method.
+func requestSpan(ctx context.Context, endpoint string, attrs ...attribute.KeyValue) (context.Context, func(*error)) {
+	ctx, span := tracer.Start(ctx, "consoleme."+endpoint, trace.WithAttributes(attrs...))
+	start := time.Now()
+
+	return ctx, func(errp *error) {
+		duration := time.Since(start).Seconds()
+		code := ""
+		var err error
+		if errp != nil {
+			err = *errp
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if cmErr, ok := err.(*werrors.ConsoleMeError); ok {
+				code = cmErr.Code
+				span.SetAttributes(attribute.String("weep.consoleme_error_code", cmErr.Code))
+			}
+		}
+
+		attrSet := append(append([]attribute.KeyValue{}, attrs...),
+			attribute.String("endpoint", endpoint),
+			attribute.String("code", code),
+		)
+		requestsTotal.Add(ctx, 1, metric.WithAttributes(attrSet...))
+		requestDuration.Record(ctx, duration, metric.WithAttributes(attribute.String("endpoint", endpoint)))
+
+		span.End()
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sts implements creds.CredentialProvider by assuming the requested
+// role directly via STS, with no ConsoleMe (or other broker) in the loop.
+// It exists as a reference second provider to prove out the CredentialProvider
+// seam: it only depends on pkg/creds and pkg/aws, never on anything
+// ConsoleMe-specific.
+package sts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/netflix/weep/pkg/aws"
+	"github.com/netflix/weep/pkg/creds"
+)
+
+// assumeRoleSessionDuration is used as the credential expiration, since
+// aws.GetAssumeRoleCredentials (unlike the ConsoleMe providers' responses)
+// doesn't surface the actual STS-issued expiration, only the three
+// credential strings.
+const assumeRoleSessionDuration = time.Hour
+
+const providerName = "sts"
+
+func init() {
+	creds.RegisterProvider(providerName, func(region string) (creds.CredentialProvider, error) {
+		return NewProvider(region), nil
+	})
+}
+
+// Provider assumes roles directly via STS. It does not broker access
+// decisions the way ConsoleMe does: callers are expected to already have
+// permission to assume the role ARN they pass in.
+type Provider struct {
+	Region string
+}
+
+// NewProvider returns an STS-backed CredentialProvider for the given region.
+func NewProvider(region string) *Provider {
+	return &Provider{Region: region}
+}
+
+// ListRoles is not supported by the STS provider: STS has no concept of
+// "eligible roles", since it performs no authorization of its own.
+func (p *Provider) ListRoles(ctx context.Context) ([]creds.Role, error) {
+	return nil, fmt.Errorf("sts: ListRoles is not supported; the sts provider assumes roles you already have access to")
+}
+
+// GetRoleCredentials assumes role directly via STS.
+func (p *Provider) GetRoleCredentials(ctx context.Context, role string, opts creds.RoleCredentialsOptions) (*aws.Credentials, error) {
+	accessKeyID, secretAccessKey, sessionToken, err := aws.GetAssumeRoleCredentials("", "", "", role)
+	if err != nil {
+		return nil, err
+	}
+	return &aws.Credentials{
+		RoleArn:         role,
+		AccessKeyId:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+		Expiration:      time.Now().Add(assumeRoleSessionDuration),
+	}, nil
+}
+
+// SearchAccounts is not supported by the STS provider.
+func (p *Provider) SearchAccounts(ctx context.Context, query string) ([]creds.AccountDetails, error) {
+	return nil, fmt.Errorf("sts: SearchAccounts is not supported")
+}
+
+// SearchRoles is not supported by the STS provider.
+func (p *Provider) SearchRoles(ctx context.Context, account string, query string) ([]creds.Role, error) {
+	return nil, fmt.Errorf("sts: SearchRoles is not supported")
+}
+
+// ResourceURL is not supported by the STS provider: there is no console to link to.
+func (p *Provider) ResourceURL(ctx context.Context, arn string) (string, error) {
+	return "", fmt.Errorf("sts: ResourceURL is not supported")
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package creds
+
+import (
+	"context"
+	"testing"
+
+	"github.com/netflix/weep/pkg/aws"
+)
+
+type fakeProvider struct{}
+
+func (fakeProvider) ListRoles(ctx context.Context) ([]Role, error) { return nil, nil }
+
+func (fakeProvider) GetRoleCredentials(ctx context.Context, role string, opts RoleCredentialsOptions) (*aws.Credentials, error) {
+	return &aws.Credentials{RoleArn: role}, nil
+}
+
+func (fakeProvider) SearchAccounts(ctx context.Context, query string) ([]AccountDetails, error) {
+	return nil, nil
+}
+
+func (fakeProvider) SearchRoles(ctx context.Context, account string, query string) ([]Role, error) {
+	return nil, nil
+}
+
+func (fakeProvider) ResourceURL(ctx context.Context, arn string) (string, error) { return "", nil }
+
+func TestRegisterProviderAndDispatch(t *testing.T) {
+	name := "fake-test-provider"
+	RegisterProvider(name, func(region string) (CredentialProvider, error) {
+		return fakeProvider{}, nil
+	})
+
+	provider, err := newProvider(name, "us-east-1")
+	if err != nil {
+		t.Fatalf("newProvider(%q) returned error: %v", name, err)
+	}
+
+	creds, err := provider.GetRoleCredentials(context.Background(), "arn:aws:iam::123456789012:role/test", RoleCredentialsOptions{})
+	if err != nil {
+		t.Fatalf("GetRoleCredentials returned error: %v", err)
+	}
+	if creds.RoleArn != "arn:aws:iam::123456789012:role/test" {
+		t.Fatalf("unexpected role arn: %s", creds.RoleArn)
+	}
+}
+
+func TestNewProviderUnknownName(t *testing.T) {
+	if _, err := newProvider("does-not-exist", ""); err == nil {
+		t.Fatal("expected error for unknown provider name")
+	}
+}
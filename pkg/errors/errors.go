@@ -0,0 +1,91 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package errors defines the sentinel errors weep returns to callers, along
+// with a richer ConsoleMeError type that preserves the original ConsoleMe
+// response for callers that need more than a static message.
+package errors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned by the ConsoleMe client. Callers historically
+// compared against these directly; they remain valid targets for errors.Is
+// even when the underlying error is a *ConsoleMeError.
+var (
+	InvalidArn                     = errors.New("invalid ARN")
+	NoMatchingRoles                = errors.New("no matching roles found")
+	MultipleMatchingRoles          = errors.New("multiple matching roles found")
+	CredentialRetrievalError       = errors.New("failed to retrieve credentials")
+	MalformedRequestError          = errors.New("malformed request")
+	MutualTLSCertNeedsRefreshError = errors.New("mutual TLS certificate needs refresh")
+	InvalidJWT                     = errors.New("invalid or expired JWT")
+	UnexpectedResponseType         = errors.New("received unexpected response type from ConsoleMe")
+)
+
+// ConsoleMeError is a structured error returned by the ConsoleMe client. It
+// carries the information needed by CLI callers, the metadata server, and
+// IMDS handlers to render actionable messages and to programmatically branch
+// on the ConsoleMe error code (e.g. to decide whether a request is worth
+// retrying).
+type ConsoleMeError struct {
+	// Code is the ConsoleMe error code, e.g. "902".
+	Code string
+	// Message is the human-readable message returned by ConsoleMe.
+	Message string
+	// HTTPStatus is the HTTP status code of the response that produced this error.
+	HTTPStatus int
+	// RequestID is the ConsoleMe request/correlation ID, when present, to aid
+	// cross-referencing with ConsoleMe-side logs.
+	RequestID string
+	// cause is the sentinel error this code maps to, so existing callers can
+	// keep comparing against the well-known sentinels via errors.Is.
+	cause error
+}
+
+func (e *ConsoleMeError) Error() string {
+	if e.RequestID != "" {
+		return fmt.Sprintf("consoleme error %s (http %d, request_id=%s): %s", e.Code, e.HTTPStatus, e.RequestID, e.Message)
+	}
+	return fmt.Sprintf("consoleme error %s (http %d): %s", e.Code, e.HTTPStatus, e.Message)
+}
+
+// Unwrap returns the sentinel error this ConsoleMe code maps to, so that
+// errors.Unwrap and errors.As continue to work for existing callers.
+func (e *ConsoleMeError) Unwrap() error {
+	return e.cause
+}
+
+// Is reports whether target is the sentinel this ConsoleMeError maps to,
+// allowing code written against the old sentinel errors (e.g.
+// errors.Is(err, werrors.CredentialRetrievalError)) to keep working unchanged.
+func (e *ConsoleMeError) Is(target error) bool {
+	return e.cause != nil && errors.Is(e.cause, target)
+}
+
+// NewConsoleMeError builds a ConsoleMeError for the given ConsoleMe code,
+// associating it with the sentinel error callers already match against.
+func NewConsoleMeError(code string, message string, httpStatus int, requestID string, cause error) *ConsoleMeError {
+	return &ConsoleMeError{
+		Code:       code,
+		Message:    message,
+		HTTPStatus: httpStatus,
+		RequestID:  requestID,
+		cause:      cause,
+	}
+}
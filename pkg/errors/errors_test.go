@@ -0,0 +1,76 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestConsoleMeErrorIsMatchesSentinel(t *testing.T) {
+	sentinels := []error{
+		InvalidArn,
+		NoMatchingRoles,
+		MultipleMatchingRoles,
+		CredentialRetrievalError,
+		MalformedRequestError,
+		MutualTLSCertNeedsRefreshError,
+		InvalidJWT,
+		UnexpectedResponseType,
+	}
+
+	for _, sentinel := range sentinels {
+		sentinel := sentinel
+		t.Run(sentinel.Error(), func(t *testing.T) {
+			cmErr := NewConsoleMeError("900", sentinel.Error(), 500, "req-id", sentinel)
+			if !errors.Is(cmErr, sentinel) {
+				t.Errorf("errors.Is(cmErr, %v) = false, want true", sentinel)
+			}
+
+			wrapped := fmt.Errorf("while doing something: %w", cmErr)
+			if !errors.Is(wrapped, sentinel) {
+				t.Errorf("errors.Is(wrapped, %v) = false, want true", sentinel)
+			}
+
+			if !errors.Is(cmErr, cmErr) {
+				t.Errorf("errors.Is(cmErr, cmErr) = false, want true")
+			}
+		})
+	}
+}
+
+func TestConsoleMeErrorIsDoesNotMatchUnrelatedSentinel(t *testing.T) {
+	cmErr := NewConsoleMeError("902", "failed", 500, "req-id", CredentialRetrievalError)
+	if errors.Is(cmErr, InvalidArn) {
+		t.Error("errors.Is(cmErr, InvalidArn) = true, want false")
+	}
+}
+
+func TestConsoleMeErrorUnwrap(t *testing.T) {
+	cmErr := NewConsoleMeError("902", "failed", 500, "req-id", CredentialRetrievalError)
+	if got := errors.Unwrap(cmErr); got != CredentialRetrievalError {
+		t.Errorf("errors.Unwrap(cmErr) = %v, want %v", got, CredentialRetrievalError)
+	}
+}
+
+func TestConsoleMeErrorIsWithNoCause(t *testing.T) {
+	cmErr := NewConsoleMeError("000", "unexpected", 500, "req-id", nil)
+	if errors.Is(cmErr, CredentialRetrievalError) {
+		t.Error("errors.Is(cmErr, CredentialRetrievalError) = true, want false for a cause-less ConsoleMeError")
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2020 Netflix, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package telemetry wires up weep's global OpenTelemetry tracer and meter
+// providers. cmd/ calls Init once at startup, before constructing a
+// credentials provider, so every ConsoleMe request instrumented in
+// pkg/creds/provider/consoleme is exported from process start.
+package telemetry
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netflix/weep/pkg/logging"
+	"github.com/netflix/weep/pkg/metadata"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.17.0"
+
+	"github.com/spf13/viper"
+)
+
+// ShutdownFunc flushes and tears down the providers Init installed.
+type ShutdownFunc func(context.Context) error
+
+// Init configures the global tracer and meter providers from
+// telemetry.exporter ("otlp", "prometheus", or "" to disable) and
+// telemetry.endpoint. Both tracing and metrics are always wired up together
+// so a single config choice can't silently leave one signal dark: "otlp"
+// exports both over OTLP/HTTP to telemetry.endpoint, while "prometheus"
+// exports metrics via the existing /metrics endpoint and traces via OTLP/HTTP
+// (Prometheus has no trace format of its own). It returns a ShutdownFunc
+// callers should defer so buffered spans/metrics are flushed on exit.
+func Init(ctx context.Context) (ShutdownFunc, error) {
+	exporterName := viper.GetString("telemetry.exporter")
+	if exporterName == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if exporterName != "otlp" && exporterName != "prometheus" {
+		return nil, fmt.Errorf("telemetry: unknown exporter %q (want \"otlp\" or \"prometheus\")", exporterName)
+	}
+
+	res, err := resource.Merge(
+		resource.Default(),
+		resource.NewWithAttributes(semconv.SchemaURL,
+			semconv.ServiceNameKey.String("weep"),
+			semconv.ServiceVersionKey.String(fmt.Sprintf("%s", metadata.Version)),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownTraces, err := initTraces(ctx, res)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownMetrics, err := initMetrics(ctx, res, exporterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx context.Context) error {
+		err := shutdownTraces(ctx)
+		if metricsErr := shutdownMetrics(ctx); metricsErr != nil && err == nil {
+			err = metricsErr
+		}
+		return err
+	}, nil
+}
+
+// initTraces installs an OTLP/HTTP trace exporter. OTLP is the only trace
+// format wired up today, so this runs regardless of telemetry.exporter.
+func initTraces(ctx context.Context, res *resource.Resource) (ShutdownFunc, error) {
+	endpoint := viper.GetString("telemetry.endpoint")
+	traceExporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	logging.Log.Infof("OpenTelemetry tracing configured via OTLP, endpoint=%s", endpoint)
+
+	return func(ctx context.Context) error {
+		return tp.Shutdown(ctx)
+	}, nil
+}
+
+// initMetrics installs the metrics exporter named by telemetry.exporter.
+func initMetrics(ctx context.Context, res *resource.Resource, exporterName string) (ShutdownFunc, error) {
+	switch exporterName {
+	case "otlp":
+		return initOTLPMetrics(ctx, res)
+	case "prometheus":
+		return initPrometheusMetrics(res)
+	default:
+		panic(fmt.Sprintf("telemetry: initMetrics called with unvalidated exporter %q", exporterName))
+	}
+}
+
+func initOTLPMetrics(ctx context.Context, res *resource.Resource) (ShutdownFunc, error) {
+	endpoint := viper.GetString("telemetry.endpoint")
+	metricExporter, err := otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpoint(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	mp := metric.NewMeterProvider(
+		metric.WithReader(metric.NewPeriodicReader(metricExporter)),
+		metric.WithResource(res),
+	)
+	otel.SetMeterProvider(mp)
+
+	logging.Log.Infof("OpenTelemetry metrics configured via OTLP, endpoint=%s", endpoint)
+
+	return func(ctx context.Context) error {
+		return mp.Shutdown(ctx)
+	}, nil
+}
+
+func initPrometheusMetrics(res *resource.Resource) (ShutdownFunc, error) {
+	exporter, err := prometheus.New()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Prometheus exporter: %w", err)
+	}
+
+	mp := metric.NewMeterProvider(
+		metric.WithReader(exporter),
+		metric.WithResource(res),
+	)
+	otel.SetMeterProvider(mp)
+
+	logging.Log.Infof("OpenTelemetry metrics configured via Prometheus; metrics are exposed via the existing /metrics endpoint")
+
+	return func(ctx context.Context) error {
+		return mp.Shutdown(ctx)
+	}, nil
+}